@@ -0,0 +1,71 @@
+package pathfmt
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// fieldPlan is the precomputed work ToStruct and FromStruct need for one
+// "path"-tagged struct field, so repeated calls don't re-walk the struct's
+// fields and re-read its tags via reflect every time.
+type fieldPlan struct {
+	index      int
+	name       string
+	tag        string
+	fieldType  reflect.Type
+	unexported bool
+}
+
+// typeCache memoizes the field plan for a struct type, keyed by
+// reflect.Type. It's shared across all Formats, since the plan only
+// depends on the target struct's shape, not on any particular template or
+// registered converter.
+var typeCache sync.Map // map[reflect.Type][]fieldPlan
+
+// fieldPlansFor returns the "path"-tagged fields of typ, in field order,
+// computing and caching them on first use.
+func fieldPlansFor(typ reflect.Type) []fieldPlan {
+	if v, ok := typeCache.Load(typ); ok {
+		return v.([]fieldPlan)
+	}
+
+	var plans []fieldPlan
+	for i := 0; i < typ.NumField(); i++ {
+		tf := typ.Field(i)
+		t := tf.Tag.Get(tag)
+		if t == "" {
+			continue
+		}
+
+		plans = append(plans, fieldPlan{
+			index:      i,
+			name:       tf.Name,
+			tag:        t,
+			fieldType:  tf.Type,
+			unexported: tf.PkgPath != "",
+		})
+	}
+
+	v, _ := typeCache.LoadOrStore(typ, plans)
+	return v.([]fieldPlan)
+}
+
+// Precompile warms the field-plan cache for T, so the first real
+// ToStruct/FromStruct call against T doesn't pay the cost of walking its
+// fields via reflect. It's optional: ToStruct and FromStruct populate the
+// cache themselves on first use; call this at startup if a long-running
+// server wants a predictable first request.
+//
+// Precompile panics if T is not a struct type, the same way ToStruct and
+// FromStruct return an error for a non-struct target rather than silently
+// doing nothing.
+func Precompile[T any]() {
+	var zero T
+	typ := reflect.TypeOf(zero)
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("pathfmt: Precompile requires a struct type, got %v", typ))
+	}
+
+	fieldPlansFor(typ)
+}
@@ -0,0 +1,76 @@
+package pathfmt
+
+import (
+	"reflect"
+	"testing"
+)
+
+type benchPath struct {
+	A string  `path:"a"`
+	B string  `path:"b"`
+	C int     `path:"c"`
+	D int     `path:"d"`
+	E int64   `path:"e"`
+	F uint    `path:"f"`
+	G float32 `path:"g"`
+	H float64 `path:"h"`
+	I bool    `path:"i"`
+	J string  `path:"j"`
+}
+
+func TestPrecompile(t *testing.T) {
+	Precompile[benchPath]()
+
+	plans := fieldPlansFor(reflect.TypeOf(benchPath{}))
+	if len(plans) != 10 {
+		t.Fatalf("expected 10 cached field plans, got %d", len(plans))
+	}
+}
+
+func TestPrecompileNonStructPanics(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected Precompile[int] to panic, it did not")
+		}
+
+		msg, ok := r.(string)
+		if !ok || msg == "" {
+			t.Fatalf("expected a descriptive panic message, got %v", r)
+		}
+		t.Logf("expected panic was raised: %v", r)
+	}()
+
+	Precompile[int]()
+}
+
+func BenchmarkToStruct(b *testing.B) {
+	const tmpl = "/a/{a}/b/{b}/c/{c}/d/{d}/e/{e}/f/{f}/g/{g}/h/{h}/i/{i}/j/{j}"
+	const input = "/a/abc/b/def/c/1/d/2/e/3/f/4/g/5.5/h/6.5/i/true/j/xyz"
+
+	f := New(tmpl)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var p benchPath
+		if err := f.ToStruct(input, &p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFromStruct(b *testing.B) {
+	const tmpl = "/a/{a}/b/{b}/c/{c}/d/{d}/e/{e}/f/{f}/g/{g}/h/{h}/i/{i}/j/{j}"
+
+	f := New(tmpl)
+	p := benchPath{A: "abc", B: "def", C: 1, D: 2, E: 3, F: 4, G: 5.5, H: 6.5, I: true, J: "xyz"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := f.FromStruct(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package pathfmt
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Converter parses a raw path segment into a reflect.Value of some target
+// type. Register one with Format.RegisterConverter to let ToStruct decode
+// into types it doesn't already understand, modeled on gorilla/schema's
+// Decoder.RegisterConverter.
+type Converter func(value string) (reflect.Value, error)
+
+// RegisterConverter configures f to decode fields whose type matches the
+// type of sample using fn, instead of the built-in conversions ToStruct
+// otherwise applies. Registering a converter for a type pathfmt already
+// understands (e.g. time.Time) overrides the built-in behavior.
+func (f *Format) RegisterConverter(sample interface{}, fn Converter) {
+	if f.converters == nil {
+		f.converters = map[reflect.Type]Converter{}
+	}
+	f.converters[reflect.TypeOf(sample)] = fn
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+	ipType       = reflect.TypeOf(net.IP{})
+	urlType      = reflect.TypeOf(url.URL{})
+
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// formatNamed renders v as a string if its type is one convertScalar has
+// special parsing for, or it implements encoding.TextMarshaler, so that
+// FromStruct stays the inverse of ToStruct for those types. ok is false if
+// neither applies, and the caller should fall back to kind-based
+// formatting.
+func formatNamed(v reflect.Value) (s string, ok bool, err error) {
+	switch v.Type() {
+	case timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339), true, nil
+	case durationType:
+		return v.Interface().(time.Duration).String(), true, nil
+	case uuidType:
+		return v.Interface().(uuid.UUID).String(), true, nil
+	case ipType:
+		return v.Interface().(net.IP).String(), true, nil
+	case urlType:
+		u := v.Interface().(url.URL)
+		return u.String(), true, nil
+	}
+
+	if v.CanInterface() && v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return "", true, err
+		}
+		return string(b), true, nil
+	}
+
+	return "", false, nil
+}
+
+// convert decodes v into a reflect.Value assignable to t, handling slice
+// fields by splitting v on commas and converting each element, unless a
+// converter is registered for the slice type itself.
+func (f *Format) convert(v string, t reflect.Type) (reflect.Value, error) {
+	_, registered := f.converters[t]
+	if !registered && t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		items := strings.Split(v, ",")
+		slice := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			ev, err := f.convertScalar(item, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			slice.Index(i).Set(ev)
+		}
+		return slice, nil
+	}
+
+	return f.convertScalar(v, t)
+}
+
+// convertScalar decodes v into a reflect.Value assignable to t, in order of
+// precedence: a converter registered with RegisterConverter, a
+// pathfmt-supported named type, encoding.TextUnmarshaler, then the
+// kind-based conversions ToStruct has always supported.
+func (f *Format) convertScalar(v string, t reflect.Type) (reflect.Value, error) {
+	if fn, ok := f.converters[t]; ok {
+		return fn(v)
+	}
+
+	switch t {
+	case timeType:
+		tm, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(tm), nil
+	case durationType:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(d), nil
+	case uuidType:
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(id), nil
+	case ipType:
+		ip := net.ParseIP(v)
+		if ip == nil {
+			return reflect.Value{}, fmt.Errorf("invalid IP address: %q", v)
+		}
+		return reflect.ValueOf(ip), nil
+	case urlType:
+		u, err := url.Parse(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(*u), nil
+	}
+
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		ptr := reflect.New(t)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v)); err != nil {
+			return reflect.Value{}, err
+		}
+		return ptr.Elem(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(b), nil
+	case reflect.Float32, reflect.Float64:
+		fv, err := strconv.ParseFloat(v, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(fv).Convert(t), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Base 10, not 0: base 0 would interpret a zero-padded segment like
+		// "010" as octal instead of decimal.
+		iv, err := strconv.ParseInt(v, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(iv).Convert(t), nil
+	case reflect.String:
+		return reflect.ValueOf(v), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		uv, err := strconv.ParseUint(v, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(uv).Convert(t), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("unsupported field type %v", t)
+}
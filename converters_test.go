@@ -0,0 +1,209 @@
+package pathfmt
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestToStructTypedFields(t *testing.T) {
+	type MyPath struct {
+		When     time.Time     `path:"when"`
+		TTL      time.Duration `path:"ttl"`
+		ID       uuid.UUID     `path:"id"`
+		Addr     net.IP        `path:"addr"`
+		Endpoint url.URL       `path:"endpoint"`
+		Tags     []string      `path:"tags"`
+	}
+
+	const pathTemplate = "/when/{when}/ttl/{ttl}/id/{id}/addr/{addr}/endpoint/{endpoint}/tags/{tags}"
+
+	wantWhen, err := time.Parse(time.RFC3339, "2024-01-02T15:04:05Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantID := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+
+	input := "/when/2024-01-02T15:04:05Z/ttl/90s/id/f47ac10b-58cc-0372-8567-0e02b2c3d479" +
+		"/addr/10.0.0.1/endpoint/example.com/tags/a,b,c"
+
+	template := New(pathTemplate)
+	var got MyPath
+	if err := template.ToStruct(input, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !got.When.Equal(wantWhen) {
+		t.Fatalf("expected When %v, got %v", wantWhen, got.When)
+	}
+	if got.TTL != 90*time.Second {
+		t.Fatalf("expected TTL %v, got %v", 90*time.Second, got.TTL)
+	}
+	if got.ID != wantID {
+		t.Fatalf("expected ID %v, got %v", wantID, got.ID)
+	}
+	if got.Addr.String() != "10.0.0.1" {
+		t.Fatalf("expected Addr %v, got %v", "10.0.0.1", got.Addr)
+	}
+	if got.Endpoint.String() != "example.com" {
+		t.Fatalf("expected Endpoint %v, got %v", "example.com", got.Endpoint.String())
+	}
+	if !reflect.DeepEqual(got.Tags, []string{"a", "b", "c"}) {
+		t.Fatalf("expected Tags %v, got %v", []string{"a", "b", "c"}, got.Tags)
+	}
+}
+
+// upper is a trivial encoding.TextMarshaler/TextUnmarshaler used to verify
+// that ToStruct/FromStruct fall back to it for types pathfmt has no
+// built-in support for.
+type upper string
+
+func (u *upper) UnmarshalText(text []byte) error {
+	*u = upper(text)
+	return nil
+}
+
+func (u upper) MarshalText() ([]byte, error) {
+	return []byte(u), nil
+}
+
+func TestToStructTextUnmarshaler(t *testing.T) {
+	type MyPath struct {
+		Name upper `path:"name"`
+	}
+
+	template := New("/name/{name}")
+	var got MyPath
+	if err := template.ToStruct("/name/nick", &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Name != "nick" {
+		t.Fatalf("expected Name %q, got %q", "nick", got.Name)
+	}
+}
+
+func TestToStructZeroPaddedIntsAreDecimal(t *testing.T) {
+	type MyPath struct {
+		ID  int  `path:"id"`
+		UID uint `path:"uid"`
+	}
+
+	template := New("/items/{id:int}/users/{uid:uint}")
+
+	var got MyPath
+	if err := template.ToStruct("/items/010/users/018", &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.ID != 10 {
+		t.Fatalf("expected ID 10, got %d", got.ID)
+	}
+	if got.UID != 18 {
+		t.Fatalf("expected UID 18, got %d", got.UID)
+	}
+}
+
+func TestRegisterConverter(t *testing.T) {
+	type level int
+
+	type MyPath struct {
+		Level level `path:"level"`
+	}
+
+	template := New("/level/{level}")
+	template.RegisterConverter(level(0), func(v string) (reflect.Value, error) {
+		switch v {
+		case "low":
+			return reflect.ValueOf(level(1)), nil
+		case "high":
+			return reflect.ValueOf(level(2)), nil
+		default:
+			return reflect.Value{}, nil
+		}
+	})
+
+	var got MyPath
+	if err := template.ToStruct("/level/high", &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Level != 2 {
+		t.Fatalf("expected Level %v, got %v", 2, got.Level)
+	}
+}
+
+func TestRoundTripTypedFields(t *testing.T) {
+	type MyPath struct {
+		When time.Time     `path:"when"`
+		TTL  time.Duration `path:"ttl"`
+		ID   uuid.UUID     `path:"id"`
+		Addr net.IP        `path:"addr"`
+		Tags []string      `path:"tags"`
+	}
+
+	in := MyPath{
+		When: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		TTL:  90 * time.Second,
+		ID:   uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479"),
+		Addr: net.ParseIP("10.0.0.1"),
+		Tags: []string{"a", "b", "c"},
+	}
+
+	template := New("/when/{when}/ttl/{ttl}/id/{id}/addr/{addr}/tags/{tags}")
+
+	p, err := template.FromStruct(in)
+	if err != nil {
+		t.Fatalf("FromStruct: unexpected error: %v", err)
+	}
+
+	var out MyPath
+	if err := template.ToStruct(p, &out); err != nil {
+		t.Fatalf("ToStruct: unexpected error: %v", err)
+	}
+
+	if !out.When.Equal(in.When) {
+		t.Fatalf("expected When %v, got %v", in.When, out.When)
+	}
+	if out.TTL != in.TTL {
+		t.Fatalf("expected TTL %v, got %v", in.TTL, out.TTL)
+	}
+	if out.ID != in.ID {
+		t.Fatalf("expected ID %v, got %v", in.ID, out.ID)
+	}
+	if !out.Addr.Equal(in.Addr) {
+		t.Fatalf("expected Addr %v, got %v", in.Addr, out.Addr)
+	}
+	if !reflect.DeepEqual(out.Tags, in.Tags) {
+		t.Fatalf("expected Tags %v, got %v", in.Tags, out.Tags)
+	}
+}
+
+func TestRoundTripTextMarshaler(t *testing.T) {
+	type MyPath struct {
+		Name upper `path:"name"`
+	}
+
+	template := New("/name/{name}")
+	in := MyPath{Name: "nick"}
+
+	p, err := template.FromStruct(in)
+	if err != nil {
+		t.Fatalf("FromStruct: unexpected error: %v", err)
+	}
+	if p != "/name/nick" {
+		t.Fatalf("expected %q, got %q", "/name/nick", p)
+	}
+
+	var out MyPath
+	if err := template.ToStruct(p, &out); err != nil {
+		t.Fatalf("ToStruct: unexpected error: %v", err)
+	}
+	if out.Name != in.Name {
+		t.Fatalf("expected Name %v, got %v", in.Name, out.Name)
+	}
+}
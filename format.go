@@ -3,6 +3,7 @@ package pathfmt
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -12,25 +13,34 @@ const tag = "path"
 type Format struct {
 	str   string
 	parts []part
+
+	converters map[reflect.Type]Converter
 }
 
 type part struct {
 	static   string
 	variable string
+	matcher  *regexp.Regexp
+	catchAll bool
 }
 
 // New creates a new Format from a path string.
 // The path string should be of the form:
 // "/items/{id}/subitems/{subid}"
+//
+// A variable may carry an inline constraint after a colon, e.g. "{id:int}"
+// or "{slug:[a-z-]+}" (see newVariablePart), and the final segment may be a
+// catch-all of the form "{rest...}" that captures the remainder of the path.
+// New panics if a constraint doesn't compile as a regexp (the same way
+// regexp.MustCompile does for an invalid pattern), or if a catch-all
+// variable isn't the template's last segment.
 func New(path string) *Format {
 	var parts []part
 	splt := split(path)
 
 	for _, s := range splt {
 		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
-			parts = append(parts, part{
-				variable: strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}"),
-			})
+			parts = append(parts, newVariablePart(strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")))
 		} else {
 			parts = append(parts, part{
 				static: s,
@@ -38,6 +48,12 @@ func New(path string) *Format {
 		}
 	}
 
+	for i, p := range parts {
+		if p.catchAll && i != len(parts)-1 {
+			panic(fmt.Sprintf("pathfmt: catch-all variable %q must be the last segment in template %q", p.variable, path))
+		}
+	}
+
 	return &Format{
 		str:   path,
 		parts: parts,
@@ -72,65 +88,23 @@ func (f *Format) ToStruct(s string, x interface{}) error {
 		return fmt.Errorf("expected struct, got %v", el.Kind())
 	}
 
-	typ := el.Type()
-	for i := 0; i < typ.NumField(); i++ {
-		ef := el.Field(i)
-		tf := typ.Field(i)
-		tag := tf.Tag.Get(tag)
-
-		if !ef.CanSet() {
-			if tag != "" {
-				// There's an "path" tag on a private field, we can't alter it, and it's
-				// likely a mistake. Return an error so the user can handle.
-				return fmt.Errorf("private fields with %q tags are unexported: %q", tag, tf.Name)
-			}
-
-			// Otherwise continue to the next field.
-			continue
+	for _, p := range fieldPlansFor(el.Type()) {
+		if p.unexported {
+			// There's an "path" tag on a private field, we can't alter it, and it's
+			// likely a mistake. Return an error so the user can handle.
+			return fmt.Errorf("private fields with %q tags are unexported: %q", p.tag, p.name)
 		}
 
-		v, ok := m[tag]
+		v, ok := m[p.tag]
 		if !ok {
 			continue
 		}
 
-		tft := tf.Type
-
-		switch ef.Kind() {
-		case reflect.Bool:
-			b, err := strconv.ParseBool(v)
-			if err != nil {
-				return err
-			}
-			ef.SetBool(b)
-		case reflect.Float32, reflect.Float64:
-			f, err := strconv.ParseFloat(v, tft.Bits())
-			if err != nil {
-				return err
-			}
-			ef.SetFloat(f)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
-			i, err := strconv.ParseInt(v, 0, tft.Bits())
-			if err != nil {
-				return err
-			}
-			ef.SetInt(i)
-		case reflect.Int64:
-			// Special case time.Duration values.
-			i, err := strconv.ParseInt(v, 0, tft.Bits())
-			if err != nil {
-				return err
-			}
-			ef.SetInt(i)
-		case reflect.String:
-			ef.SetString(v)
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			i, err := strconv.ParseUint(v, 0, tft.Bits())
-			if err != nil {
-				return err
-			}
-			ef.SetUint(i)
+		cv, err := f.convert(v, p.fieldType)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", p.name, err)
 		}
+		el.Field(p.index).Set(cv)
 	}
 
 	return nil
@@ -141,32 +115,96 @@ func (f *Format) ToStruct(s string, x interface{}) error {
 // for a template like
 // "/items/{id}/subitems/{subid}"
 // into a map with key-pairs "id":"123" and "subid":"xyz".
+//
+// If a variable carries an inline constraint (see New), a segment that
+// doesn't satisfy it returns an error. A trailing catch-all variable
+// ("{rest...}") consumes the remainder of the path, joined back together
+// with "/", as a single map entry instead of being truncated.
 func (f *Format) ToMap(path string) (map[string]string, error) {
 	splt := split(path)
 
 	m := map[string]string{}
 
-	for i, s := range splt {
-		if i >= len(f.parts) {
+	for i, p := range f.parts {
+		if i >= len(splt) {
 			break
 		}
 
-		if f.parts[i].variable != "" {
-			m[f.parts[i].variable] = s
-		} else {
-			if f.parts[i].static != s {
-				return nil, fmt.Errorf("expected format %q: got %q: expected string %q, got %q", f.str, path, f.parts[i].static, s)
+		if p.catchAll {
+			m[p.variable] = strings.Join(splt[i:], "/")
+			break
+		}
+
+		s := splt[i]
+
+		if p.variable != "" {
+			if p.matcher != nil && !p.matcher.MatchString(s) {
+				return nil, fmt.Errorf("path %q: segment %q does not satisfy constraint for variable %q", path, s, p.variable)
 			}
+			m[p.variable] = s
+			continue
+		}
+
+		if p.static != s {
+			return nil, fmt.Errorf("expected format %q: got %q: expected string %q, got %q", f.str, path, p.static, s)
 		}
 	}
 
 	return m, nil
 }
 
+// Match reports whether path has exactly the segments f's template expects
+// (a trailing catch-all absorbs any number of trailing segments, but
+// requires at least one), with every static segment and constraint
+// satisfied. Unlike ToMap, which tolerates a shorter or longer path so that
+// partial matches are still useful, Match is for callers that need to
+// disambiguate between templates with overlapping prefixes, e.g. a router
+// choosing between "/users/{id}" and "/users/{id}/posts/{postId}".
+func (f *Format) Match(path string) bool {
+	_, ok := f.MatchMap(path)
+	return ok
+}
+
+// MatchMap is Match followed by ToMap in a single pass: it reports whether
+// path fully matches f's template and, if so, also returns the parsed path
+// variables, so a caller that needs both doesn't have to parse path twice.
+func (f *Format) MatchMap(path string) (map[string]string, bool) {
+	splt := split(path)
+
+	if last := f.parts[len(f.parts)-1]; last.catchAll {
+		if len(splt) < len(f.parts) {
+			return nil, false
+		}
+	} else if len(splt) != len(f.parts) {
+		return nil, false
+	}
+
+	m, err := f.ToMap(path)
+	if err != nil {
+		return nil, false
+	}
+
+	return m, true
+}
+
 func split(s string) []string {
 	return strings.Split(strings.TrimPrefix(s, "/"), "/")
 }
 
+// FromStruct is the inverse of ToStruct: it renders a path like
+// "/items/123/subitems/xyz" from a template like
+// "/items/{id}/subitems/{subid}"
+// and a struct with fields Id and SubId:
+//
+//	type MyPath struct {
+//	    Id    int    `path:"id"`
+//	    SubId string `path:"subid"`
+//	}
+//
+// Field values are formatted with the same conventions ToStruct uses to
+// parse them, so that f.ToStruct(path, &x) after path, _ = f.FromStruct(x)
+// round-trips for supported field kinds. It returns an error if s has no
+// field tagged with one of the template's variables.
 func (f *Format) FromStruct(s interface{}) (string, error) {
 	parts := make([]string, len(f.parts))
 	for i, p := range f.parts {
@@ -181,30 +219,90 @@ func (f *Format) FromStruct(s interface{}) (string, error) {
 		}
 	}
 
+	return f.join(parts), nil
+}
+
+// FromMap is the inverse of ToMap: it renders a path like
+// "/items/123/subitems/xyz" from a template like
+// "/items/{id}/subitems/{subid}"
+// and a map with key-pairs "id":"123" and "subid":"xyz". It returns an
+// error if m is missing a value for one of the template's variables.
+func (f *Format) FromMap(m map[string]string) (string, error) {
+	parts := make([]string, len(f.parts))
+	for i, p := range f.parts {
+		if p.variable != "" {
+			v, ok := m[p.variable]
+			if !ok {
+				return "", fmt.Errorf("missing value for variable %q", p.variable)
+			}
+			parts[i] = v
+		} else {
+			parts[i] = p.static
+		}
+	}
+
+	return f.join(parts), nil
+}
+
+// join re-assembles the parts of a path produced by FromStruct/FromMap,
+// preserving the leading "/" of the template if it had one.
+func (f *Format) join(parts []string) string {
 	prefix := ""
 	if strings.HasPrefix(f.str, "/") {
 		prefix = "/"
 	}
 
-	return prefix + strings.Join(parts, "/"), nil
+	return prefix + strings.Join(parts, "/")
 }
 
-// structField returns the value of a field in a struct
-// with "path" tag.
+// structField returns the string representation of the field in s tagged
+// with "path:"<field>"", formatted to match how ToStruct parses that kind.
 func structField(s interface{}, field string) (string, error) {
 	val := reflect.ValueOf(s)
 	if val.Kind() != reflect.Struct {
 		return "", fmt.Errorf("expected struct, got %v", val.Kind())
 	}
 
-	typ := val.Type()
-	for i := 0; i < typ.NumField(); i++ {
-		f := typ.Field(i)
-		if f.Tag.Get(tag) == field {
-			// Return the string value of the field.
-			return fmt.Sprintf("%v", val.Field(i).Interface()), nil
+	for _, p := range fieldPlansFor(val.Type()) {
+		if p.tag == field {
+			return formatValue(val.Field(p.index))
 		}
 	}
 
 	return "", fmt.Errorf("field %q not found", field)
 }
+
+// formatValue renders v as a string using the same conventions ToStruct
+// uses to parse it.
+func formatValue(v reflect.Value) (string, error) {
+	if s, ok, err := formatNamed(v); ok {
+		return s, err
+	}
+
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		items := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s, err := formatValue(v.Index(i))
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return strings.Join(items, ","), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("unsupported field kind %v", v.Kind())
+	}
+}
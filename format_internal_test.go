@@ -0,0 +1,412 @@
+package pathfmt
+
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []part
+	}{
+		{
+			input: "/items/{id}/subitems/{subid}",
+			expected: []part{
+				{
+					static: "items",
+				},
+				{
+					variable: "id",
+				},
+				{
+					static: "subitems",
+				},
+				{
+					variable: "subid",
+				},
+			},
+		},
+		{
+			input: "/files/{rest...}",
+			expected: []part{
+				{
+					static: "files",
+				},
+				{
+					variable: "rest",
+					catchAll: true,
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			tmpl := New(c.input)
+
+			if len(tmpl.parts) != len(c.expected) {
+				t.Fatalf("expected %d named parts, got %d", len(c.expected), len(tmpl.parts))
+			}
+
+			for i, np := range tmpl.parts {
+				if np != c.expected[i] {
+					t.Fatalf("expected named part %v, got %v", c.expected[i], np)
+				}
+			}
+		})
+	}
+}
+func TestToMap(t *testing.T) {
+	cases := []struct {
+		name        string
+		template    string
+		input       string
+		expectError bool
+		expected    map[string]string
+	}{
+		{
+			name:     "exact-match",
+			template: "/items/{id}/subitems/{subid}",
+			input:    "/items/123/subitems/456",
+			expected: map[string]string{
+				"id":    "123",
+				"subid": "456",
+			},
+		},
+		{
+			name:     "shorter-input",
+			template: "/items/{id}/subitems/{subid}",
+			input:    "/items/123/subitems",
+			expected: map[string]string{
+				"id": "123",
+			},
+		},
+		{
+			name:     "longer-input",
+			template: "/items/{id}/subitems/{subid}",
+			input:    "/items/123/subitems/456/extra",
+			expected: map[string]string{
+				"id":    "123",
+				"subid": "456",
+			},
+		},
+		{
+			name:        "invalid-static-part",
+			template:    "/items/{id}/subitems/{subid}",
+			input:       "/items/123/invalid/456",
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			template := New(c.template)
+			m, err := template.ToMap(c.input)
+
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				t.Logf("expected error was returned: %v", err)
+				return
+			} else {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			}
+
+			if len(m) != len(c.expected) {
+				t.Fatalf("expected %d values, got %d", len(c.expected), len(m))
+			}
+
+			for k, v := range c.expected {
+				if m[k] != v {
+					t.Fatalf("expected %s=%s, got %s=%s", k, v, k, m[k])
+				}
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		input    string
+		expected bool
+	}{
+		{
+			name:     "exact-match",
+			template: "/users/{id}",
+			input:    "/users/5",
+			expected: true,
+		},
+		{
+			name:     "shorter-input-does-not-match",
+			template: "/users/{id}/posts/{postId}",
+			input:    "/users/5",
+			expected: false,
+		},
+		{
+			name:     "longer-input-does-not-match",
+			template: "/users/{id}",
+			input:    "/users/5/posts/9",
+			expected: false,
+		},
+		{
+			name:     "invalid-static-part-does-not-match",
+			template: "/users/{id}/posts/{postId}",
+			input:    "/users/5/invalid/9",
+			expected: false,
+		},
+		{
+			name:     "constraint-mismatch-does-not-match",
+			template: "/users/{id:int}",
+			input:    "/users/abc",
+			expected: false,
+		},
+		{
+			name:     "catch-all-matches-one-or-more-trailing-segments",
+			template: "/files/{rest...}",
+			input:    "/files/a/b/c.txt",
+			expected: true,
+		},
+		{
+			name:     "catch-all-requires-at-least-one-segment",
+			template: "/files/{rest...}",
+			input:    "/files",
+			expected: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			template := New(c.template)
+			if got := template.Match(c.input); got != c.expected {
+				t.Fatalf("expected Match(%q) = %v, got %v", c.input, c.expected, got)
+			}
+		})
+	}
+}
+
+func TestMatchMap(t *testing.T) {
+	template := New("/users/{id}/posts/{postId}")
+
+	m, ok := template.MatchMap("/users/5/posts/9")
+	if !ok {
+		t.Fatalf("expected MatchMap to report a match")
+	}
+	if m["id"] != "5" || m["postId"] != "9" {
+		t.Fatalf("expected id=5 postId=9, got %v", m)
+	}
+
+	if _, ok := template.MatchMap("/users/5"); ok {
+		t.Fatalf("expected MatchMap to report no match for a shorter input")
+	}
+}
+
+func TestToStruct(t *testing.T) {
+	const pathTemplate = "/a/{a}/b/{b}/c/{c}/d/{d}"
+	type MyPath struct {
+		A string  `path:"a"`
+		B int     `path:"b"`
+		C float64 `path:"c"`
+		D bool    `path:"d"`
+	}
+
+	cases := []struct {
+		name        string
+		input       string
+		expectError bool
+		expected    MyPath
+	}{
+		{
+			name:  "all-values",
+			input: "/a/abc/b/123/c/3.14/d/true",
+			expected: MyPath{
+				A: "abc",
+				B: 123,
+				C: 3.14,
+				D: true,
+			},
+		},
+		{
+			name:  "missing-values",
+			input: "/a/abc/b/123",
+			expected: MyPath{
+				A: "abc",
+				B: 123,
+			},
+		},
+		{
+			name:  "extra-values",
+			input: "/a/abc/b/123/c/3.14/d/true/e/extra",
+			expected: MyPath{
+				A: "abc",
+				B: 123,
+				C: 3.14,
+				D: true,
+			},
+		},
+		{
+			name:        "bad-type",
+			input:       "/a/abc/b/xyz",
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			template := New(pathTemplate)
+			var path MyPath
+
+			err := template.ToStruct(c.input, &path)
+
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				t.Logf("expected error was returned: %v", err)
+				return
+			} else {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			}
+
+			if path != c.expected {
+				t.Fatalf("expected %v, got %v", c.expected, path)
+			}
+		})
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	cases := []struct {
+		name        string
+		template    string
+		input       map[string]string
+		expectError bool
+		expected    string
+	}{
+		{
+			name:     "all-values",
+			template: "/items/{id}/subitems/{subid}",
+			input: map[string]string{
+				"id":    "123",
+				"subid": "456",
+			},
+			expected: "/items/123/subitems/456",
+		},
+		{
+			name:        "missing-value",
+			template:    "/items/{id}/subitems/{subid}",
+			input:       map[string]string{"id": "123"},
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			template := New(c.template)
+			got, err := template.FromMap(c.input)
+
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				t.Logf("expected error was returned: %v", err)
+				return
+			} else if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+
+			if got != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestFromStruct(t *testing.T) {
+	const pathTemplate = "/a/{a}/b/{b}/c/{c}/d/{d}"
+	type MyPath struct {
+		A string  `path:"a"`
+		B int     `path:"b"`
+		C float64 `path:"c"`
+		D bool    `path:"d"`
+	}
+
+	cases := []struct {
+		name        string
+		input       interface{}
+		expectError bool
+		expected    string
+	}{
+		{
+			name: "all-values",
+			input: MyPath{
+				A: "abc",
+				B: 123,
+				C: 3.14,
+				D: true,
+			},
+			expected: "/a/abc/b/123/c/3.14/d/true",
+		},
+		{
+			name:        "missing-field",
+			input:       struct{}{},
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			template := New(pathTemplate)
+			got, err := template.FromStruct(c.input)
+
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				t.Logf("expected error was returned: %v", err)
+				return
+			} else if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+
+			if got != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	const pathTemplate = "/a/{a}/b/{b}/c/{c}/d/{d}/e/{e}"
+	type MyPath struct {
+		A string  `path:"a"`
+		B int     `path:"b"`
+		C float64 `path:"c"`
+		D bool    `path:"d"`
+		E uint    `path:"e"`
+	}
+
+	in := MyPath{A: "abc", B: -123, C: 3.5, D: true, E: 7}
+
+	template := New(pathTemplate)
+
+	p, err := template.FromStruct(in)
+	if err != nil {
+		t.Fatalf("FromStruct: unexpected error: %v", err)
+	}
+
+	var out MyPath
+	if err := template.ToStruct(p, &out); err != nil {
+		t.Fatalf("ToStruct: unexpected error: %v", err)
+	}
+
+	if out != in {
+		t.Fatalf("round trip did not preserve value: expected %v, got %v", in, out)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/nstogner/pathfmt"
 )
 
-func ExampleToMap() {
+func ExampleFormat_ToMap() {
 	f := pathfmt.New("/api/v1/users/{id}")
 
 	m, err := f.ToMap("/api/v1/users/123")
@@ -19,7 +19,7 @@ func ExampleToMap() {
 	// Output: map[id:123]
 }
 
-func ExampleToStruct() {
+func ExampleFormat_ToStruct() {
 	type UserIdentifier struct {
 		OrgNum int    `path:"org_num"`
 		ID     string `path:"id"`
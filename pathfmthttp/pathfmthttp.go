@@ -0,0 +1,92 @@
+// Package pathfmthttp wires pathfmt path templates into net/http, letting
+// callers route on the same "/items/{id}" templates pathfmt already parses
+// instead of hand-rolling path splitting in every handler.
+package pathfmthttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nstogner/pathfmt"
+)
+
+// Handler adapts a typed path-parameter callback into an http.Handler. The
+// template's variables are parsed from r.URL.Path with pathfmt.New(template)
+// into a new *T via Format.ToStruct and passed to fn. If parsing fails,
+// Handler responds with http.StatusBadRequest and fn is never called.
+func Handler[T any](template string, fn func(w http.ResponseWriter, r *http.Request, params *T)) http.Handler {
+	f := pathfmt.New(template)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params T
+		if err := f.ToStruct(r.URL.Path, &params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fn(w, r, &params)
+	})
+}
+
+// Mux is an http.Handler that dispatches requests to handlers registered
+// against pathfmt path templates, in the order they were registered.
+type Mux struct {
+	routes []route
+}
+
+type route struct {
+	method  string
+	format  *pathfmt.Format
+	handler http.Handler
+}
+
+// NewMux creates an empty Mux.
+func NewMux() *Mux {
+	return &Mux{}
+}
+
+// Handle registers h for requests matching method and the given path
+// template (as accepted by pathfmt.New). An empty method matches any
+// request method.
+func (m *Mux) Handle(method, template string, h http.Handler) {
+	m.routes = append(m.routes, route{
+		method:  method,
+		format:  pathfmt.New(template),
+		handler: h,
+	})
+}
+
+// ServeHTTP implements http.Handler. It finds the first registered route
+// whose method matches the request and whose template fully matches the
+// request path (see Format.MatchMap) — so routes with overlapping prefixes,
+// like "/users/{id}" and "/users/{id}/posts/{postId}", don't cross-match
+// regardless of registration order — stores the parsed path variables on
+// the request context (retrievable with Params), and dispatches to that
+// route's handler. If no route matches, it responds with
+// http.StatusNotFound.
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, rt := range m.routes {
+		if rt.method != "" && rt.method != r.Method {
+			continue
+		}
+
+		params, ok := rt.format.MatchMap(r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		rt.handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), paramsKey{}, params)))
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+type paramsKey struct{}
+
+// Params returns the path variables that Mux parsed for the current
+// request, keyed by the same names used in the path template. It returns
+// nil if called outside of a handler dispatched by a Mux.
+func Params(r *http.Request) map[string]string {
+	m, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return m
+}
@@ -0,0 +1,116 @@
+package pathfmthttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nstogner/pathfmt/pathfmthttp"
+)
+
+func ExampleHandler() {
+	type UserPath struct {
+		ID string `path:"id"`
+	}
+
+	h := pathfmthttp.Handler("/users/{id}", func(w http.ResponseWriter, r *http.Request, params *UserPath) {
+		fmt.Fprintf(w, "user %s", params.ID)
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/nick", nil))
+
+	fmt.Println(rec.Body.String())
+	// Output: user nick
+}
+
+func ExampleMux() {
+	mux := pathfmthttp.NewMux()
+	mux.Handle(http.MethodGet, "/users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "user %s", pathfmthttp.Params(r)["id"])
+	}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/nick", nil))
+
+	fmt.Println(rec.Body.String())
+	// Output: user nick
+}
+
+func TestMuxOverlappingRoutes(t *testing.T) {
+	mux := pathfmthttp.NewMux()
+	mux.Handle(http.MethodGet, "/users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "user %s", pathfmthttp.Params(r)["id"])
+	}))
+	mux.Handle(http.MethodGet, "/users/{id}/posts/{postId}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := pathfmthttp.Params(r)
+		fmt.Fprintf(w, "user %s post %s", params["id"], params["postId"])
+	}))
+
+	cases := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "shorter-prefix-route", path: "/users/5", want: "user 5"},
+		{name: "longer-suffix-route", path: "/users/5/posts/9", want: "user 5 post 9"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, c.path, nil))
+
+			if got := rec.Body.String(); got != c.want {
+				t.Fatalf("expected body %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestMuxOverlappingRoutesRegisteredInReverseOrder(t *testing.T) {
+	mux := pathfmthttp.NewMux()
+	mux.Handle(http.MethodGet, "/users/{id}/posts/{postId}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := pathfmthttp.Params(r)
+		fmt.Fprintf(w, "user %s post %s", params["id"], params["postId"])
+	}))
+	mux.Handle(http.MethodGet, "/users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "user %s", pathfmthttp.Params(r)["id"])
+	}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/5", nil))
+
+	if got, want := rec.Body.String(), "user 5"; got != want {
+		t.Fatalf("expected body %q, got %q", want, got)
+	}
+}
+
+func TestMuxNotFound(t *testing.T) {
+	mux := pathfmthttp.NewMux()
+	mux.Handle(http.MethodGet, "/users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "user %s", pathfmthttp.Params(r)["id"])
+	}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/5/posts/9", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestMuxMethodMismatch(t *testing.T) {
+	mux := pathfmthttp.NewMux()
+	mux.Handle(http.MethodGet, "/users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "user %s", pathfmthttp.Params(r)["id"])
+	}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/5", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,53 @@
+package pathfmt
+
+import (
+	"regexp"
+	"strings"
+)
+
+// newVariablePart parses the contents of a "{...}" path segment: a bare
+// name ("id"), a name with an inline constraint ("id:int", "slug:[a-z-]+"),
+// or a catch-all ("rest...").
+func newVariablePart(inner string) part {
+	if strings.HasSuffix(inner, "...") {
+		return part{
+			variable: strings.TrimSuffix(inner, "..."),
+			catchAll: true,
+		}
+	}
+
+	name, constraint, ok := strings.Cut(inner, ":")
+	if !ok {
+		return part{variable: name}
+	}
+
+	return part{
+		variable: name,
+		matcher:  constraintMatcher(constraint),
+	}
+}
+
+// builtinConstraints maps the built-in type names usable after a colon in a
+// template, e.g. "{id:int}", to the regexp they enforce.
+var builtinConstraints = map[string]string{
+	"int":   `-?[0-9]+`,
+	"uint":  `[0-9]+`,
+	"float": `-?[0-9]+(?:\.[0-9]+)?`,
+	// Matches whatever strconv.ParseBool accepts, so a value that satisfies
+	// the constraint is guaranteed to decode successfully.
+	"bool": `1|t|T|TRUE|true|True|0|f|F|FALSE|false|False`,
+	"uuid": `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+}
+
+// constraintMatcher compiles a variable's inline constraint into a
+// full-match regexp. constraint is either a built-in type name, a "re:"
+// prefixed pattern, or a bare regexp (e.g. "[a-z-]+"). It panics if the
+// constraint doesn't compile, the same way regexp.MustCompile does.
+func constraintMatcher(constraint string) *regexp.Regexp {
+	pattern, ok := builtinConstraints[constraint]
+	if !ok {
+		pattern = strings.TrimPrefix(constraint, "re:")
+	}
+
+	return regexp.MustCompile(`^(?:` + pattern + `)$`)
+}
@@ -0,0 +1,106 @@
+package pathfmt
+
+import "testing"
+
+func TestToMapConstraints(t *testing.T) {
+	cases := []struct {
+		name        string
+		template    string
+		input       string
+		expectError bool
+		expected    map[string]string
+	}{
+		{
+			name:     "int-matches",
+			template: "/items/{id:int}",
+			input:    "/items/-42",
+			expected: map[string]string{"id": "-42"},
+		},
+		{
+			name:        "int-rejects-non-digits",
+			template:    "/items/{id:int}",
+			input:       "/items/abc",
+			expectError: true,
+		},
+		{
+			name:     "regexp-matches",
+			template: "/posts/{slug:[a-z-]+}",
+			input:    "/posts/hello-world",
+			expected: map[string]string{"slug": "hello-world"},
+		},
+		{
+			name:        "regexp-rejects",
+			template:    "/posts/{slug:[a-z-]+}",
+			input:       "/posts/Hello_World",
+			expectError: true,
+		},
+		{
+			name:     "bool-accepts-parsebool-forms",
+			template: "/flags/{flag:bool}",
+			input:    "/flags/T",
+			expected: map[string]string{"flag": "T"},
+		},
+		{
+			name:        "bool-rejects-non-parsebool",
+			template:    "/flags/{flag:bool}",
+			input:       "/flags/yes",
+			expectError: true,
+		},
+		{
+			name:     "uuid-matches",
+			template: "/users/{id:uuid}",
+			input:    "/users/f47ac10b-58cc-0372-8567-0e02b2c3d479",
+			expected: map[string]string{"id": "f47ac10b-58cc-0372-8567-0e02b2c3d479"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			template := New(c.template)
+			m, err := template.ToMap(c.input)
+
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				t.Logf("expected error was returned: %v", err)
+				return
+			} else if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+
+			if len(m) != len(c.expected) {
+				t.Fatalf("expected %d values, got %d", len(c.expected), len(m))
+			}
+
+			for k, v := range c.expected {
+				if m[k] != v {
+					t.Fatalf("expected %s=%s, got %s=%s", k, v, k, m[k])
+				}
+			}
+		})
+	}
+}
+
+func TestToMapCatchAll(t *testing.T) {
+	template := New("/files/{rest...}")
+
+	m, err := template.ToMap("/files/a/b/c.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m["rest"] != "a/b/c.txt" {
+		t.Fatalf("expected rest=%q, got rest=%q", "a/b/c.txt", m["rest"])
+	}
+}
+
+func TestNewPanicsOnNonTrailingCatchAll(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected New to panic on a non-trailing catch-all, it did not")
+		}
+	}()
+
+	New("/a/{rest...}/b")
+}